@@ -0,0 +1,258 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// causer mirrors the unexported interface that github.com/pkg/errors uses
+// internally for Cause(). Matching it structurally lets us walk a cause
+// chain without importing pkg/errors directly; stack trace extraction,
+// which does need pkg/errors' concrete types, lives behind
+// RegisterStackTraceExtractor instead so that dependency stays optional.
+type causer interface {
+	Cause() error
+}
+
+// stackExtractorFunc is the concrete type stored in stackTraceExtractor.
+// atomic.Value requires a consistent concrete type across Store calls, so
+// RegisterStackTraceExtractor always wraps its argument in this type rather
+// than storing the bare func(error) ([]string, bool) type.
+type stackExtractorFunc func(error) ([]string, bool)
+
+// stackTraceExtractor is supplied by an integration package (for example,
+// a pkgerrors subpackage built on github.com/pkg/errors) that knows how to
+// pull "file:line function" frames out of errors it recognizes. Core zap
+// has no hard dependency on any particular stacktrace library; callers who
+// never register an extractor simply don't get a "stack" field. It's an
+// atomic.Value, not a plain var, because RegisterStackTraceExtractor may be
+// called concurrently with in-flight RichError/Errors calls.
+var stackTraceExtractor atomic.Value
+
+// RegisterStackTraceExtractor installs extract as the function RichError
+// uses to render an error's stack frames. Integration packages call this
+// from an init function so that only applications that import them pay for
+// (or require) the underlying stacktrace library. Calling it again replaces
+// the previous extractor; it's safe to call concurrently with logging.
+func RegisterStackTraceExtractor(extract func(error) ([]string, bool)) {
+	stackTraceExtractor.Store(stackExtractorFunc(extract))
+}
+
+// loadStackTraceExtractor returns the currently registered extractor, if
+// any.
+func loadStackTraceExtractor() (stackExtractorFunc, bool) {
+	v := stackTraceExtractor.Load()
+	if v == nil {
+		return nil, false
+	}
+	return v.(stackExtractorFunc), true
+}
+
+// RichError constructs a Field that stores err under the key "error". If err
+// exposes a Cause() chain (as github.com/pkg/errors' wrapped errors do)
+// and/or a registered stack trace extractor recognizes it, the field
+// expands into a structured object with "message", "verbose" (every cause
+// level's message, interleaved with that level's own stack frames, ordered
+// from the original failure to the outermost wrap — the same information
+// pkg/errors' "%+v" renders), "stack" (all levels' frames concatenated in
+// that same order), and "causes" (the Cause() chain, unwrapped until it
+// terminates). When no level yields stack frames, "verbose" is just the
+// error's own message. Errors that match neither degrade to the same single-string
+// behavior as Error. Passing a nil error returns a no-op field.
+func RichError(err error) Field {
+	if err == nil {
+		return Skip()
+	}
+	if !isStructuredError(err) {
+		return Error(err)
+	}
+	return Marshaler("error", richError{err})
+}
+
+// isStructuredError reports whether err exposes enough of pkg/errors' shape
+// to be worth the structured treatment.
+func isStructuredError(err error) bool {
+	if _, ok := err.(causer); ok {
+		return true
+	}
+	extract, ok := loadStackTraceExtractor()
+	if !ok {
+		return false
+	}
+	_, ok = extract(err)
+	return ok
+}
+
+type richError struct {
+	err error
+}
+
+func (r richError) MarshalLog(kv KeyValue) error {
+	msg := r.err.Error()
+	kv.AddString("message", msg)
+
+	levels := causeLevels(r.err)
+	extract, hasExtractor := loadStackTraceExtractor()
+
+	// Walk innermost-to-outermost, the same order pkg/errors' own "%+v"
+	// prints: the original failure first, each wrap's message and stack
+	// layered on top of it. Each level's frames are extracted exactly once
+	// and reused for both "verbose" and "stack", so this costs one walk, not
+	// the two a naive fmt.Sprintf("%+v", r.err) plus a separate extraction
+	// pass would.
+	var allFrames []string
+	verboseLines := make([]string, 0, len(levels))
+	for i := len(levels) - 1; i >= 0; i-- {
+		lvl := levels[i]
+		verboseLines = append(verboseLines, lvl.Error())
+		if hasExtractor {
+			if frames, ok := extract(lvl); ok {
+				allFrames = append(allFrames, frames...)
+				verboseLines = append(verboseLines, frames...)
+			}
+		}
+	}
+
+	verbose := msg
+	if len(allFrames) > 0 {
+		verbose = strings.Join(verboseLines, "\n")
+	}
+	kv.AddString("verbose", verbose)
+
+	if len(allFrames) > 0 {
+		if err := kv.AddObject("stack", allFrames); err != nil {
+			return err
+		}
+	}
+
+	if len(levels) > 1 {
+		causes := make([]string, 0, len(levels)-1)
+		for _, lvl := range levels[1:] {
+			causes = append(causes, lvl.Error())
+		}
+		if err := kv.AddObject("causes", causes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// causeLevels walks Cause() until it stops returning a new error, returning
+// err itself followed by each distinct cause along the way. Adjacent hops
+// whose Error() is identical are collapsed into one level:
+// github.com/pkg/errors' Wrap produces two Cause() hops per wrap level (a
+// *withStack wrapping a *withMessage), and the withStack hop always repeats
+// the withMessage hop's Error() verbatim, since withStack.Error() is just
+// promoted from it.
+func causeLevels(err error) []error {
+	levels := []error{err}
+	prevMsg := err.Error()
+	for {
+		c, ok := err.(causer)
+		if !ok {
+			return levels
+		}
+		cause := c.Cause()
+		if cause == nil || cause == err {
+			return levels
+		}
+		if msg := cause.Error(); msg != prevMsg {
+			levels = append(levels, cause)
+			prevMsg = msg
+		}
+		err = cause
+	}
+}
+
+// causeChain returns the messages of every cause after err itself; see
+// causeLevels for the dedup rules.
+func causeChain(err error) []string {
+	levels := causeLevels(err)
+	if len(levels) <= 1 {
+		return nil
+	}
+	causes := make([]string, 0, len(levels)-1)
+	for _, lvl := range levels[1:] {
+		causes = append(causes, lvl.Error())
+	}
+	return causes
+}
+
+// errorsProvider mirrors go.uber.org/multierr's exported shape. Matching it
+// structurally means Errors can fan out multierr values without importing
+// the package, so the dependency stays optional for callers who don't use it.
+type errorsProvider interface {
+	Errors() []error
+}
+
+// Errors constructs a Field that stores err under the given key as an array
+// of structured error objects. If err implements the `Errors() []error`
+// method that go.uber.org/multierr errors expose, each constituent error
+// becomes its own array element; a plain error is wrapped in a one-element
+// array so consumers can rely on a stable array schema. Every element
+// carries the same baseline {"index", "message", "verbose"}, plus
+// "stack"/"causes" when that element supports them, same as RichError. A
+// nil error returns a no-op field, matching Error. Nil entries within a
+// multierr's Errors() slice are skipped rather than marshaled, since a nil
+// error has no message to report.
+func Errors(key string, err error) Field {
+	if err == nil {
+		return Skip()
+	}
+	return Array(key, errArray{err})
+}
+
+type errArray struct {
+	err error
+}
+
+func (e errArray) MarshalLogArray(arr ArrayEncoder) error {
+	errs := []error{e.err}
+	if multi, ok := e.err.(errorsProvider); ok {
+		errs = multi.Errors()
+	}
+
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if err := arr.AppendMarshaler(errElement{index: i, err: err}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// errElement marshals a single error from an Errors() fan-out. Every
+// element gets the same richError treatment, so the array has one schema
+// regardless of whether a given element happens to be pkg/errors-flavored.
+type errElement struct {
+	index int
+	err   error
+}
+
+func (e errElement) MarshalLog(kv KeyValue) error {
+	kv.AddInt("index", e.index)
+	return richError{e.err}.MarshalLog(kv)
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// testStackTraceExtractor is a test-local stand-in for the real extractor
+// that the pkgerrors subpackage registers, so these tests can exercise
+// RichError's multi-level stack handling without importing that subpackage
+// (which itself imports this one).
+type stackTracer interface {
+	StackTrace() pkgerrors.StackTrace
+}
+
+func testStackTraceExtractor(err error) ([]string, bool) {
+	st, ok := err.(stackTracer)
+	if !ok {
+		return nil, false
+	}
+	trace := st.StackTrace()
+	frames := make([]string, len(trace))
+	for i, f := range trace {
+		frames[i] = fmt.Sprintf("%s:%d %n", f, f, f)
+	}
+	return frames, true
+}
+
+func noopStackTraceExtractor(error) ([]string, bool) {
+	return nil, false
+}
+
+func TestCauseChainDedupesPkgErrorsWrapLevels(t *testing.T) {
+	// errors.Wrap nests a *withStack over a *withMessage per call, so each
+	// logical wrap level is two Cause() hops whose Error() is identical.
+	// Without deduping, a three-level chain yields a repeated middle entry
+	// instead of one clean message per level.
+	base := errors.New("base")
+	mid := pkgerrors.Wrap(base, "mid")
+	top := pkgerrors.Wrap(mid, "top")
+
+	got := causeChain(top)
+	want := []string{"mid: base", "base"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("causeChain(top) = %#v, want %#v", got, want)
+	}
+}
+
+func TestCauseChainPlainError(t *testing.T) {
+	if got := causeChain(errors.New("boom")); len(got) != 0 {
+		t.Fatalf("causeChain(plain error) = %#v, want empty", got)
+	}
+}
+
+// Real pkg/errors renders a distinct stack trace per wrap level in its
+// "%+v" output: RichError's "stack" and "verbose" fields must do the same,
+// not just capture the outermost Wrap call's frames.
+func TestRichErrorCapturesEveryWrapLevelsStack(t *testing.T) {
+	RegisterStackTraceExtractor(testStackTraceExtractor)
+	t.Cleanup(func() { RegisterStackTraceExtractor(noopStackTraceExtractor) })
+
+	base := errors.New("base")
+	mid := pkgerrors.Wrap(base, "mid")
+	top := pkgerrors.Wrap(mid, "top")
+
+	kv := newRecordingKV()
+	RichError(top).AddTo(kv)
+
+	nested, ok := kv.values["error"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("error value = %#v, want map[string]interface{}", kv.values["error"])
+	}
+
+	stack, ok := nested["stack"].([]string)
+	if !ok {
+		t.Fatalf("stack value = %#v, want []string", nested["stack"])
+	}
+
+	midFrames, _ := testStackTraceExtractor(mid)
+	topFrames, _ := testStackTraceExtractor(top)
+	if len(midFrames) == 0 || len(topFrames) == 0 {
+		t.Fatalf("test setup broken: expected both mid and top to carry their own frames")
+	}
+	if len(stack) != len(midFrames)+len(topFrames) {
+		t.Fatalf("len(stack) = %d, want %d (mid's %d frames + top's %d frames)", len(stack), len(midFrames)+len(topFrames), len(midFrames), len(topFrames))
+	}
+	if !reflect.DeepEqual(stack[:len(midFrames)], midFrames) {
+		t.Errorf("stack does not lead with mid's (innermost wrap's) frames: %#v", stack)
+	}
+	if !reflect.DeepEqual(stack[len(midFrames):], topFrames) {
+		t.Errorf("stack does not end with top's (outermost wrap's) frames: %#v", stack)
+	}
+
+	verbose, ok := nested["verbose"].(string)
+	if !ok {
+		t.Fatalf("verbose value = %#v, want string", nested["verbose"])
+	}
+	if !strings.Contains(verbose, "base") || !strings.Contains(verbose, "mid: base") {
+		t.Errorf("verbose = %q, want it to mention both base's and mid's messages", verbose)
+	}
+}
+
+// RegisterStackTraceExtractor and RichError/Errors must be safe to call
+// concurrently; run with -race to confirm. The atomic.Value swap is the
+// fix, so this test's only real job is to give the race detector something
+// to watch.
+func TestRegisterStackTraceExtractorConcurrentSafe(t *testing.T) {
+	RegisterStackTraceExtractor(testStackTraceExtractor)
+	t.Cleanup(func() { RegisterStackTraceExtractor(noopStackTraceExtractor) })
+
+	err := pkgerrors.Wrap(errors.New("base"), "wrapped")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			RegisterStackTraceExtractor(testStackTraceExtractor)
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		kv := newRecordingKV()
+		RichError(err).AddTo(kv)
+	}
+	<-done
+}
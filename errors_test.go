@@ -0,0 +1,118 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// multiErrStub mimics go.uber.org/multierr's exported shape without
+// depending on it.
+type multiErrStub struct {
+	errs []error
+}
+
+func (m multiErrStub) Error() string {
+	msgs := make([]string, 0, len(m.errs))
+	for _, err := range m.errs {
+		if err == nil {
+			continue
+		}
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (m multiErrStub) Errors() []error {
+	return m.errs
+}
+
+func TestErrorsProducesUniformArraySchema(t *testing.T) {
+	plain := errors.New("boom")
+	multi := multiErrStub{errs: []error{errors.New("first"), errors.New("second")}}
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"plain error wraps to a one-element array", plain, 1},
+		{"multierr fans out to one element per error", multi, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kv := newRecordingKV()
+			Errors("errs", tt.err).AddTo(kv)
+
+			elements, ok := kv.values["errs"].([]map[string]interface{})
+			if !ok {
+				t.Fatalf("errs value = %#v, want []map[string]interface{}", kv.values["errs"])
+			}
+			if len(elements) != tt.want {
+				t.Fatalf("len(elements) = %d, want %d", len(elements), tt.want)
+			}
+			for i, elem := range elements {
+				for _, key := range []string{"index", "message", "verbose"} {
+					if _, ok := elem[key]; !ok {
+						t.Errorf("element %d missing %q: %#v", i, key, elem)
+					}
+				}
+				if got := elem["index"]; got != i {
+					t.Errorf("element %d index = %v, want %d", i, got, i)
+				}
+			}
+		})
+	}
+}
+
+func TestErrorsNilReturnsSkip(t *testing.T) {
+	f := Errors("errs", nil)
+	if f.fieldType != skipType {
+		t.Fatalf("Errors(nil) fieldType = %v, want skipType", f.fieldType)
+	}
+}
+
+// A nil entry inside a multierr's Errors() slice must not crash the log
+// call: it's skipped, rather than marshaled, since it has no message to
+// report.
+func TestErrorsSkipsNilEntries(t *testing.T) {
+	multi := multiErrStub{errs: []error{errors.New("a"), nil, errors.New("c")}}
+
+	kv := newRecordingKV()
+	Errors("errs", multi).AddTo(kv)
+
+	elements, ok := kv.values["errs"].([]map[string]interface{})
+	if !ok {
+		t.Fatalf("errs value = %#v, want []map[string]interface{}", kv.values["errs"])
+	}
+	if len(elements) != 2 {
+		t.Fatalf("len(elements) = %d, want 2 (nil entry skipped)", len(elements))
+	}
+	if got := elements[0]["index"]; got != 0 {
+		t.Errorf("elements[0] index = %v, want 0", got)
+	}
+	if got := elements[1]["index"]; got != 2 {
+		t.Errorf("elements[1] index = %v, want 2 (original position, not renumbered)", got)
+	}
+}
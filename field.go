@@ -33,12 +33,26 @@ const (
 	unknownType fieldType = iota
 	boolType
 	floatType
+	float32Type
 	intType
 	int64Type
+	uintType
+	uint64Type
+	uintptrType
+	complex64Type
+	complex128Type
 	stringType
+	binaryType
+	byteStringType
 	marshalerType
+	arrayMarshalerType
 	objectType
 	stringerType
+	intsType
+	stringsType
+	float64sType
+	durationsType
+	timesType
 	skipType
 )
 
@@ -90,11 +104,59 @@ func Int64(key string, val int64) Field {
 	return Field{key: key, fieldType: int64Type, ival: val}
 }
 
+// Uint constructs a Field with the given key and value.
+func Uint(key string, val uint) Field {
+	return Field{key: key, fieldType: uintType, ival: int64(val)}
+}
+
+// Uint64 constructs a Field with the given key and value.
+func Uint64(key string, val uint64) Field {
+	return Field{key: key, fieldType: uint64Type, ival: int64(val)}
+}
+
+// Uintptr constructs a Field with the given key and value.
+func Uintptr(key string, val uintptr) Field {
+	return Field{key: key, fieldType: uintptrType, ival: int64(val)}
+}
+
+// Float32 constructs a Field with the given key and value. The way the
+// floating-point value is represented is encoder-dependent.
+func Float32(key string, val float32) Field {
+	return Field{key: key, fieldType: float32Type, ival: int64(math.Float32bits(val))}
+}
+
+// Complex64 constructs a Field with the given key and value. The
+// encoder controls how the complex number is rendered (e.g. "a+bi").
+func Complex64(key string, val complex64) Field {
+	return Field{key: key, fieldType: complex64Type, obj: val}
+}
+
+// Complex128 constructs a Field with the given key and value. The
+// encoder controls how the complex number is rendered (e.g. "a+bi").
+func Complex128(key string, val complex128) Field {
+	return Field{key: key, fieldType: complex128Type, obj: val}
+}
+
 // String constructs a Field with the given key and value.
 func String(key string, val string) Field {
 	return Field{key: key, fieldType: stringType, str: val}
 }
 
+// Binary constructs a Field that carries an opaque blob of bytes. Unlike
+// Base64, the bytes aren't encoded at call time, so encoders that prefer a
+// different representation (hex, raw-escaped, ...) than base64 don't pay for
+// a conversion they're just going to discard.
+func Binary(key string, val []byte) Field {
+	return Field{key: key, fieldType: binaryType, obj: val}
+}
+
+// ByteString constructs a Field that carries UTF-8 encoded text as a []byte.
+// To avoid losing information, encoders should treat it like a string. Use
+// Binary for arbitrary, non-text bytes.
+func ByteString(key string, val []byte) Field {
+	return Field{key: key, fieldType: byteStringType, obj: val}
+}
+
 // Stringer constructs a Field with the given key and the output of the value's
 // String method.
 func Stringer(key string, val fmt.Stringer) Field {
@@ -143,6 +205,36 @@ func Duration(key string, val time.Duration) Field {
 	return Int64(key, int64(val))
 }
 
+// Ints constructs a Field that carries a slice of integers.
+func Ints(key string, vals []int) Field {
+	return Field{key: key, fieldType: intsType, obj: vals}
+}
+
+// Strings constructs a Field that carries a slice of strings.
+func Strings(key string, vals []string) Field {
+	return Field{key: key, fieldType: stringsType, obj: vals}
+}
+
+// Float64s constructs a Field that carries a slice of floats.
+func Float64s(key string, vals []float64) Field {
+	return Field{key: key, fieldType: float64sType, obj: vals}
+}
+
+// Durations constructs a Field that carries a slice of durations.
+func Durations(key string, vals []time.Duration) Field {
+	return Field{key: key, fieldType: durationsType, obj: vals}
+}
+
+// Times constructs a Field that carries a slice of times.
+func Times(key string, vals []time.Time) Field {
+	return Field{key: key, fieldType: timesType, obj: vals}
+}
+
+// Errors is a multierr-aware Field constructor; see its definition in
+// error.go. It isn't repeated here since, unlike the other array
+// constructors above, it needs to special-case the fan-out of aggregated
+// errors rather than just wrapping a slice.
+
 // Marshaler constructs a field with the given key and zap.LogMarshaler. It
 // provides a flexible, but still type-safe and efficient, way to add
 // user-defined types to the logging context.
@@ -150,6 +242,28 @@ func Marshaler(key string, val LogMarshaler) Field {
 	return Field{key: key, fieldType: marshalerType, obj: val}
 }
 
+// ArrayEncoder is the array-shaped counterpart to KeyValue: it appends
+// ordered values instead of adding keyed ones, so an ArrayMarshaler's
+// elements are encoded as indices of a real array rather than as object
+// keys "0", "1", ....
+type ArrayEncoder interface {
+	AppendMarshaler(LogMarshaler) error
+}
+
+// ArrayMarshaler is the array-producing counterpart to LogMarshaler: it lets
+// a type add itself to the logging context as an ordered sequence of
+// LogMarshaler elements instead of a single keyed object.
+type ArrayMarshaler interface {
+	MarshalLogArray(ArrayEncoder) error
+}
+
+// Array constructs a field with the given key and zap.ArrayMarshaler. It's
+// the array counterpart to Marshaler, for user-defined types that are
+// naturally a sequence (e.g. Errors' fan-out) rather than a keyed object.
+func Array(key string, val ArrayMarshaler) Field {
+	return Field{key: key, fieldType: arrayMarshalerType, obj: val}
+}
+
 // Object constructs a field with the given key and an arbitrary object. It uses
 // an encoding-appropriate, reflection-based function to serialize nearly any
 // object into the logging context, but it's relatively slow and allocation-heavy.
@@ -176,16 +290,44 @@ func (f Field) AddTo(kv KeyValue) {
 		kv.AddBool(f.key, f.ival == 1)
 	case floatType:
 		kv.AddFloat64(f.key, math.Float64frombits(uint64(f.ival)))
+	case float32Type:
+		kv.AddFloat32(f.key, math.Float32frombits(uint32(f.ival)))
 	case intType:
 		kv.AddInt(f.key, int(f.ival))
 	case int64Type:
 		kv.AddInt64(f.key, f.ival)
+	case uintType:
+		kv.AddUint(f.key, uint(f.ival))
+	case uint64Type:
+		kv.AddUint64(f.key, uint64(f.ival))
+	case uintptrType:
+		kv.AddUintptr(f.key, uintptr(f.ival))
+	case complex64Type:
+		kv.AddComplex64(f.key, f.obj.(complex64))
+	case complex128Type:
+		kv.AddComplex128(f.key, f.obj.(complex128))
 	case stringType:
 		kv.AddString(f.key, f.str)
+	case binaryType:
+		kv.AddBinary(f.key, f.obj.([]byte))
+	case byteStringType:
+		kv.AddByteString(f.key, f.obj.([]byte))
+	case intsType:
+		kv.AddInts(f.key, f.obj.([]int))
+	case stringsType:
+		kv.AddStrings(f.key, f.obj.([]string))
+	case float64sType:
+		kv.AddFloat64s(f.key, f.obj.([]float64))
+	case durationsType:
+		kv.AddDurations(f.key, f.obj.([]time.Duration))
+	case timesType:
+		kv.AddTimes(f.key, f.obj.([]time.Time))
 	case stringerType:
 		kv.AddString(f.key, f.obj.(fmt.Stringer).String())
 	case marshalerType:
 		err = kv.AddMarshaler(f.key, f.obj.(LogMarshaler))
+	case arrayMarshalerType:
+		err = kv.AddArray(f.key, f.obj.(ArrayMarshaler))
 	case objectType:
 		err = kv.AddObject(f.key, f.obj)
 	case skipType:
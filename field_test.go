@@ -0,0 +1,163 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import "testing"
+
+// sinkField keeps the benchmarked/measured calls from being optimized away as
+// dead stores; sinkU/sinkU64/sinkUptr/sinkF32 feed them non-constant inputs.
+var (
+	sinkField Field
+	sinkU     uint    = 100000 // outside the runtime's small-integer interface cache (0-255), and not a constant at the call site
+	sinkU64   uint64  = 100000
+	sinkUptr  uintptr = 100000
+	sinkF32   float32 = 100000.5
+)
+
+// The typed constructors backed by Field.ival (Uint, Uint64, Uintptr,
+// Float32, ...) should never box their value into Field.obj, unlike Object,
+// which always does since it has to hold an arbitrary interface{}.
+func TestTypedConstructorsAvoidBoxing(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		sinkField = Uint("n", sinkU)
+	})
+	if allocs != 0 {
+		t.Errorf("Uint allocs/op = %v, want 0", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(100, func() {
+		sinkField = Uint64("n", sinkU64)
+	})
+	if allocs != 0 {
+		t.Errorf("Uint64 allocs/op = %v, want 0", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(100, func() {
+		sinkField = Uintptr("n", sinkUptr)
+	})
+	if allocs != 0 {
+		t.Errorf("Uintptr allocs/op = %v, want 0", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(100, func() {
+		sinkField = Float32("n", sinkF32)
+	})
+	if allocs != 0 {
+		t.Errorf("Float32 allocs/op = %v, want 0", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(100, func() {
+		sinkField = Object("n", sinkU)
+	})
+	if allocs == 0 {
+		t.Errorf("Object allocs/op = %v, want > 0 (boxing uint into interface{})", allocs)
+	}
+}
+
+// Unlike the ival-backed scalars above, the constructors that can't fit in
+// Field.ival (Complex128, Binary, the slice constructors, ...) still box
+// their value into Field.obj, exactly like Object does. They're only
+// "allocation-free" relative to Object's reflection-based encoding, not in
+// the absolute sense the ival-backed constructors are.
+func TestBoxedConstructorsAllocateLikeObject(t *testing.T) {
+	vals := []string{"a", "b", "c"}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		sinkField = Strings("ns", vals)
+	})
+	if allocs == 0 {
+		t.Errorf("Strings allocs/op = %v, want > 0 (boxing []string into interface{})", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(100, func() {
+		sinkField = Object("ns", vals)
+	})
+	if allocs == 0 {
+		t.Errorf("Object allocs/op = %v, want > 0 (boxing []string into interface{})", allocs)
+	}
+}
+
+func BenchmarkFieldUint(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkField = Uint("n", sinkU)
+	}
+}
+
+func BenchmarkFieldObjectUint(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkField = Object("n", sinkU)
+	}
+}
+
+func BenchmarkFieldUint64(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkField = Uint64("n", sinkU64)
+	}
+}
+
+func BenchmarkFieldUintptr(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkField = Uintptr("n", sinkUptr)
+	}
+}
+
+func BenchmarkFieldFloat32(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkField = Float32("n", sinkF32)
+	}
+}
+
+func BenchmarkFieldInts(b *testing.B) {
+	b.ReportAllocs()
+	vals := []int{1, 2, 3}
+	for i := 0; i < b.N; i++ {
+		sinkField = Ints("ns", vals)
+	}
+}
+
+func BenchmarkFieldObjectInts(b *testing.B) {
+	b.ReportAllocs()
+	vals := []int{1, 2, 3}
+	for i := 0; i < b.N; i++ {
+		sinkField = Object("ns", vals)
+	}
+}
+
+func BenchmarkFieldStrings(b *testing.B) {
+	b.ReportAllocs()
+	vals := []string{"a", "b", "c"}
+	for i := 0; i < b.N; i++ {
+		sinkField = Strings("ns", vals)
+	}
+}
+
+func BenchmarkFieldObjectStrings(b *testing.B) {
+	b.ReportAllocs()
+	vals := []string{"a", "b", "c"}
+	for i := 0; i < b.N; i++ {
+		sinkField = Object("ns", vals)
+	}
+}
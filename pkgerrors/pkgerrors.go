@@ -0,0 +1,62 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package pkgerrors teaches zap.RichError how to render github.com/pkg/errors
+// stack traces. Most zap users don't use pkg/errors, so core zap has no
+// import of it; blank-import this package to register the extractor:
+//
+//	import _ "github.com/insionng/zap/pkgerrors"
+package pkgerrors
+
+import (
+	"fmt"
+
+	"github.com/insionng/zap"
+	"github.com/pkg/errors"
+)
+
+func init() {
+	zap.RegisterStackTraceExtractor(extractStackTrace)
+}
+
+// stackTracer mirrors the unexported interface that github.com/pkg/errors
+// uses internally. Matching it structurally lets us recognize pkg/errors
+// values (and anything that wraps them the same way) without depending on
+// any of its concrete error types.
+type stackTracer interface {
+	StackTrace() errors.StackTrace
+}
+
+// extractStackTrace renders a pkg/errors.StackTrace as "file:line function"
+// lines, relying on errors.Frame's own fmt.Formatter implementation rather
+// than reaching into its unexported fields.
+func extractStackTrace(err error) ([]string, bool) {
+	st, ok := err.(stackTracer)
+	if !ok {
+		return nil, false
+	}
+
+	trace := st.StackTrace()
+	frames := make([]string, len(trace))
+	for i, f := range trace {
+		frames[i] = fmt.Sprintf("%s:%d %n", f, f, f)
+	}
+	return frames, true
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import "time"
+
+// recordingKV is a minimal KeyValue double that records whatever was added
+// to it, so Field.AddTo's behavior can be asserted on without a real
+// encoder.
+type recordingKV struct {
+	values map[string]interface{}
+}
+
+func newRecordingKV() *recordingKV {
+	return &recordingKV{values: make(map[string]interface{})}
+}
+
+func (kv *recordingKV) AddBool(key string, val bool)             { kv.values[key] = val }
+func (kv *recordingKV) AddFloat64(key string, val float64)       { kv.values[key] = val }
+func (kv *recordingKV) AddFloat32(key string, val float32)       { kv.values[key] = val }
+func (kv *recordingKV) AddInt(key string, val int)               { kv.values[key] = val }
+func (kv *recordingKV) AddInt64(key string, val int64)           { kv.values[key] = val }
+func (kv *recordingKV) AddUint(key string, val uint)             { kv.values[key] = val }
+func (kv *recordingKV) AddUint64(key string, val uint64)         { kv.values[key] = val }
+func (kv *recordingKV) AddUintptr(key string, val uintptr)       { kv.values[key] = val }
+func (kv *recordingKV) AddComplex64(key string, val complex64)   { kv.values[key] = val }
+func (kv *recordingKV) AddComplex128(key string, val complex128) { kv.values[key] = val }
+func (kv *recordingKV) AddString(key string, val string)         { kv.values[key] = val }
+func (kv *recordingKV) AddBinary(key string, val []byte)         { kv.values[key] = val }
+func (kv *recordingKV) AddByteString(key string, val []byte)     { kv.values[key] = val }
+func (kv *recordingKV) AddInts(key string, val []int)            { kv.values[key] = val }
+func (kv *recordingKV) AddStrings(key string, val []string)      { kv.values[key] = val }
+func (kv *recordingKV) AddFloat64s(key string, val []float64)    { kv.values[key] = val }
+
+func (kv *recordingKV) AddDurations(key string, val []time.Duration) { kv.values[key] = val }
+func (kv *recordingKV) AddTimes(key string, val []time.Time)         { kv.values[key] = val }
+
+func (kv *recordingKV) AddMarshaler(key string, val LogMarshaler) error {
+	nested := newRecordingKV()
+	if err := val.MarshalLog(nested); err != nil {
+		return err
+	}
+	kv.values[key] = nested.values
+	return nil
+}
+
+func (kv *recordingKV) AddObject(key string, val interface{}) error {
+	kv.values[key] = val
+	return nil
+}
+
+func (kv *recordingKV) AddArray(key string, val ArrayMarshaler) error {
+	enc := &recordingArrayEncoder{}
+	if err := val.MarshalLogArray(enc); err != nil {
+		return err
+	}
+	kv.values[key] = enc.elements
+	return nil
+}
+
+var _ KeyValue = (*recordingKV)(nil)
+
+// recordingArrayEncoder is the ArrayEncoder counterpart to recordingKV: it
+// records each appended element as its own keyed snapshot, in order.
+type recordingArrayEncoder struct {
+	elements []map[string]interface{}
+}
+
+func (a *recordingArrayEncoder) AppendMarshaler(val LogMarshaler) error {
+	nested := newRecordingKV()
+	if err := val.MarshalLog(nested); err != nil {
+		return err
+	}
+	a.elements = append(a.elements, nested.values)
+	return nil
+}
+
+var _ ArrayEncoder = (*recordingArrayEncoder)(nil)